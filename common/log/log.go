@@ -0,0 +1,7 @@
+package log
+
+import "fmt"
+
+func Error(format string, args ...interface{}) error {
+	return fmt.Errorf(format, args...)
+}
@@ -2,37 +2,83 @@ package net
 
 import (
 	"net"
-	"strconv"
+	"strings"
 
 	"github.com/v2ray/v2ray-core/common/log"
 )
 
-// Address represents a network address to be communicated with. It may be an IP address or domain
-// address, not both. This interface doesn't resolve IP address for a given domain.
+// AddressFamily is the type of address, either IPv4, IPv6, or Domain.
+type AddressFamily int
+
+const (
+	AddressFamilyIPv4 = AddressFamily(iota)
+	AddressFamilyIPv6
+	AddressFamilyDomain
+)
+
+// Either returns true if current AddressFamily matches one of the given families.
+func (f AddressFamily) Either(families ...AddressFamily) bool {
+	for _, fa := range families {
+		if f == fa {
+			return true
+		}
+	}
+	return false
+}
+
+// IsIPv4 returns true if current AddressFamily is IPv4.
+func (f AddressFamily) IsIPv4() bool {
+	return f == AddressFamilyIPv4
+}
+
+// IsIPv6 returns true if current AddressFamily is IPv6.
+func (f AddressFamily) IsIPv6() bool {
+	return f == AddressFamilyIPv6
+}
+
+// IsIP returns true if current AddressFamily is either IPv4 or IPv6.
+func (f AddressFamily) IsIP() bool {
+	return f == AddressFamilyIPv4 || f == AddressFamilyIPv6
+}
+
+// IsDomain returns true if current AddressFamily is Domain.
+func (f AddressFamily) IsDomain() bool {
+	return f == AddressFamilyDomain
+}
+
+// Address represents the host of a network address. It may be an IP address or a domain address,
+// not both. This interface doesn't resolve IP address for a given domain, and it carries no port
+// information; see Destination for a host bundled with a port and a network.
 type Address interface {
-	IP() net.IP        // IP of this Address
-	Domain() string    // Domain of this Address
-	Port() uint16      // Port of this Address
-	PortBytes() []byte // Port in bytes, network byte order
+	IP() net.IP            // IP of this Address
+	Domain() string        // Domain of this Address
+	Family() AddressFamily // Family of this Address
 
 	IsIPv4() bool   // True if this Address is an IPv4 address
 	IsIPv6() bool   // True if this Address is an IPv6 address
 	IsDomain() bool // True if this Address is an domain address
 
+	Equals(Address) bool // True if this Address is the same as the given one
+
 	String() string // String representation of this Address
 }
 
-// IPAddress creates an Address with given IP and port.
-func IPAddress(ip []byte, port uint16) Address {
+// IPAddress creates an Address with given IP. A 16-byte IPv4-mapped IPv6 address (::ffff:a.b.c.d)
+// is normalized into an IPv4Address, so callers passing buffers from net.IP.To16() don't end up
+// with two different representations of the same address.
+func IPAddress(ip []byte) Address {
 	switch len(ip) {
 	case net.IPv4len:
 		return IPv4Address{
-			PortAddress: PortAddress{port: port},
-			ip:          [4]byte{ip[0], ip[1], ip[2], ip[3]},
+			ip: [4]byte{ip[0], ip[1], ip[2], ip[3]},
 		}
 	case net.IPv6len:
+		if isIPv4MappedIPv6(ip) {
+			return IPv4Address{
+				ip: [4]byte{ip[12], ip[13], ip[14], ip[15]},
+			}
+		}
 		return IPv6Address{
-			PortAddress: PortAddress{port: port},
 			ip: [16]byte{
 				ip[0], ip[1], ip[2], ip[3],
 				ip[4], ip[5], ip[6], ip[7],
@@ -45,28 +91,55 @@ func IPAddress(ip []byte, port uint16) Address {
 	}
 }
 
-// DomainAddress creates an Address with given domain and port.
-func DomainAddress(domain string, port uint16) Address {
-	return DomainAddressImpl{
-		domain:      domain,
-		PortAddress: PortAddress{port: port},
+// isIPv4MappedIPv6 returns true if ip is a 16-byte buffer holding an IPv4-mapped IPv6 address,
+// i.e. bytes 0-9 are zero and bytes 10-11 are 0xff.
+func isIPv4MappedIPv6(ip []byte) bool {
+	for i := 0; i < 10; i++ {
+		if ip[i] != 0 {
+			return false
+		}
 	}
+	return ip[10] == 0xff && ip[11] == 0xff
 }
 
-type PortAddress struct {
-	port uint16
+// DomainAddress creates an Address with given domain.
+func DomainAddress(domain string) Address {
+	return DomainAddressImpl{
+		domain: domain,
+	}
 }
 
-func (addr PortAddress) Port() uint16 {
-	return addr.port
-}
+// ParseAddress parses a raw string into an Address. The input may be a bare IP, a bracketed IPv6
+// address, a "host:port" pair (the port is discarded), or a domain name.
+func ParseAddress(raw string) Address {
+	host := raw
+	if h, _, err := net.SplitHostPort(raw); err == nil {
+		host = h
+	} else if strings.HasPrefix(raw, "[") && strings.HasSuffix(raw, "]") {
+		host = raw[1 : len(raw)-1]
+	}
 
-func (addr PortAddress) PortBytes() []byte {
-	return []byte{byte(addr.port >> 8), byte(addr.port)}
+	if ip := net.ParseIP(host); ip != nil {
+		return IPAddress(ip)
+	}
+	return DomainAddress(host)
 }
 
+var (
+	// LocalHostIP is the IP address for localhost, 127.0.0.1.
+	LocalHostIP = IPAddress([]byte{127, 0, 0, 1})
+
+	// AnyIP is the IPv4 any address, 0.0.0.0.
+	AnyIP = IPAddress([]byte{0, 0, 0, 0})
+
+	// LocalHostIPv6 is the IP address for localhost in IPv6, ::1.
+	LocalHostIPv6 = IPAddress(net.IPv6loopback)
+
+	// AnyIPv6 is the IPv6 any address, ::.
+	AnyIPv6 = IPAddress(net.IPv6zero)
+)
+
 type IPv4Address struct {
-	PortAddress
 	ip [4]byte
 }
 
@@ -78,6 +151,10 @@ func (addr IPv4Address) Domain() string {
 	panic("Calling Domain() on an IPv4Address.")
 }
 
+func (addr IPv4Address) Family() AddressFamily {
+	return AddressFamilyIPv4
+}
+
 func (addr IPv4Address) IsIPv4() bool {
 	return true
 }
@@ -90,12 +167,16 @@ func (addr IPv4Address) IsDomain() bool {
 	return false
 }
 
+func (addr IPv4Address) Equals(other Address) bool {
+	otherAddr, ok := other.(IPv4Address)
+	return ok && addr.ip == otherAddr.ip
+}
+
 func (addr IPv4Address) String() string {
-	return addr.IP().String() + ":" + strconv.Itoa(int(addr.PortAddress.port))
+	return addr.IP().String()
 }
 
 type IPv6Address struct {
-	PortAddress
 	ip [16]byte
 }
 
@@ -107,6 +188,10 @@ func (addr IPv6Address) Domain() string {
 	panic("Calling Domain() on an IPv6Address.")
 }
 
+func (addr IPv6Address) Family() AddressFamily {
+	return AddressFamilyIPv6
+}
+
 func (addr IPv6Address) IsIPv4() bool {
 	return false
 }
@@ -119,12 +204,16 @@ func (addr IPv6Address) IsDomain() bool {
 	return false
 }
 
+func (addr IPv6Address) Equals(other Address) bool {
+	otherAddr, ok := other.(IPv6Address)
+	return ok && addr.ip == otherAddr.ip
+}
+
 func (addr IPv6Address) String() string {
-	return "[" + addr.IP().String() + "]:" + strconv.Itoa(int(addr.PortAddress.port))
+	return "[" + addr.IP().String() + "]"
 }
 
 type DomainAddressImpl struct {
-	PortAddress
 	domain string
 }
 
@@ -136,6 +225,10 @@ func (addr DomainAddressImpl) Domain() string {
 	return addr.domain
 }
 
+func (addr DomainAddressImpl) Family() AddressFamily {
+	return AddressFamilyDomain
+}
+
 func (addr DomainAddressImpl) IsIPv4() bool {
 	return false
 }
@@ -148,6 +241,12 @@ func (addr DomainAddressImpl) IsDomain() bool {
 	return true
 }
 
+// Equals compares domain names case-insensitively, per DNS rules.
+func (addr DomainAddressImpl) Equals(other Address) bool {
+	otherAddr, ok := other.(DomainAddressImpl)
+	return ok && strings.EqualFold(addr.domain, otherAddr.domain)
+}
+
 func (addr DomainAddressImpl) String() string {
-	return addr.domain + ":" + strconv.Itoa(int(addr.PortAddress.port))
+	return addr.domain
 }
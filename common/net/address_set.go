@@ -0,0 +1,44 @@
+package net
+
+import (
+	"strconv"
+	"strings"
+)
+
+// AddressSet is a set of Destinations, used by routing rules, DNS caches, and connection-reuse
+// pools to test membership without hand-rolled comparisons. Keys are canonicalized so that
+// domain names match case-insensitively, consistent with Address.Equals.
+type AddressSet struct {
+	cache map[string]bool
+}
+
+// NewAddressSet creates a new empty AddressSet.
+func NewAddressSet() *AddressSet {
+	return &AddressSet{
+		cache: make(map[string]bool),
+	}
+}
+
+// Add puts dest into this AddressSet.
+func (s *AddressSet) Add(dest Destination) {
+	s.cache[destinationKey(dest)] = true
+}
+
+// Has returns true if dest is in this AddressSet.
+func (s *AddressSet) Has(dest Destination) bool {
+	return s.cache[destinationKey(dest)]
+}
+
+// destinationKey builds a canonical string key for dest, lower-casing domain names so that
+// lookups match Address.Equals rather than Go's case-sensitive struct equality. The family is
+// included so a domain and an IP address that happen to share a string form (e.g. domain
+// "1.2.3.4" vs IPAddress 1.2.3.4) can never collide.
+func destinationKey(dest Destination) string {
+	var host string
+	if dest.Address.IsDomain() {
+		host = strings.ToLower(dest.Address.Domain())
+	} else {
+		host = dest.Address.IP().String()
+	}
+	return dest.Network.String() + ":" + strconv.Itoa(int(dest.Address.Family())) + ":" + host + ":" + strconv.Itoa(int(dest.Port))
+}
@@ -0,0 +1,55 @@
+package net
+
+import (
+	"testing"
+)
+
+func TestAddressSetDomainCaseInsensitive(t *testing.T) {
+	set := NewAddressSet()
+	set.Add(NewTCPDestination(DomainAddress("Example.com"), Port(443)))
+
+	if !set.Has(NewTCPDestination(DomainAddress("example.com"), Port(443))) {
+		t.Error("expected AddressSet to match domain case-insensitively")
+	}
+	if !set.Has(NewTCPDestination(DomainAddress("EXAMPLE.COM"), Port(443))) {
+		t.Error("expected AddressSet to match domain case-insensitively")
+	}
+	if set.Has(NewTCPDestination(DomainAddress("other.com"), Port(443))) {
+		t.Error("did not expect AddressSet to match a different domain")
+	}
+}
+
+func TestAddressSetIPv4MappedIPv6(t *testing.T) {
+	set := NewAddressSet()
+	set.Add(NewTCPDestination(IPAddress([]byte{1, 2, 3, 4}), Port(80)))
+
+	mapped := IPAddress([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 1, 2, 3, 4})
+	if !set.Has(NewTCPDestination(mapped, Port(80))) {
+		t.Error("expected AddressSet to match an IPv4-mapped IPv6 address against its IPv4 form")
+	}
+}
+
+func TestAddressSetDomainDoesNotCollideWithIP(t *testing.T) {
+	set := NewAddressSet()
+	set.Add(NewTCPDestination(DomainAddress("1.2.3.4"), Port(80)))
+
+	if set.Has(NewTCPDestination(IPAddress([]byte{1, 2, 3, 4}), Port(80))) {
+		t.Error("did not expect a domain address to collide with an IP address of the same string form")
+	}
+}
+
+func TestDomainAddressEquals(t *testing.T) {
+	a := DomainAddress("Example.com")
+	b := DomainAddress("example.com")
+	c := DomainAddress("other.com")
+
+	if !a.Equals(b) {
+		t.Error("expected domain addresses to be equal case-insensitively")
+	}
+	if a.Equals(c) {
+		t.Error("did not expect different domains to be equal")
+	}
+	if a.Equals(IPAddress([]byte{1, 2, 3, 4})) {
+		t.Error("did not expect a domain address to equal an IP address")
+	}
+}
@@ -0,0 +1,74 @@
+package net
+
+import (
+	"testing"
+)
+
+func TestIPAddressNormalizesIPv4MappedIPv6(t *testing.T) {
+	mapped := []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 1, 2, 3, 4}
+	addr := IPAddress(mapped)
+	if !addr.IsIPv4() {
+		t.Errorf("expected an IPv4-mapped IPv6 buffer to normalize to IPv4Address, got %v", addr)
+	}
+	if addr.IP().String() != "1.2.3.4" {
+		t.Errorf("expected 1.2.3.4, got %s", addr.IP().String())
+	}
+}
+
+func TestIPAddressKeepsPlainIPv6(t *testing.T) {
+	ip := []byte{0x20, 0x01, 0xd, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}
+	addr := IPAddress(ip)
+	if !addr.IsIPv6() {
+		t.Errorf("expected a plain IPv6 buffer to stay IPv6Address, got %v", addr)
+	}
+}
+
+func TestParseAddressHostPort(t *testing.T) {
+	addr := ParseAddress("1.2.3.4:443")
+	if !addr.IsIPv4() || addr.IP().String() != "1.2.3.4" {
+		t.Errorf("expected 1.2.3.4, got %v", addr)
+	}
+}
+
+func TestParseAddressBracketedIPv6(t *testing.T) {
+	addr := ParseAddress("[::1]:443")
+	if !addr.IsIPv6() || addr.IP().String() != "::1" {
+		t.Errorf("expected ::1, got %v", addr)
+	}
+}
+
+func TestParseAddressBareIPv6(t *testing.T) {
+	addr := ParseAddress("::1")
+	if !addr.IsIPv6() || addr.IP().String() != "::1" {
+		t.Errorf("expected ::1, got %v", addr)
+	}
+}
+
+func TestParseAddressBareDomain(t *testing.T) {
+	addr := ParseAddress("example.com")
+	if !addr.IsDomain() || addr.Domain() != "example.com" {
+		t.Errorf("expected domain example.com, got %v", addr)
+	}
+}
+
+func TestParseAddressDomainWithPort(t *testing.T) {
+	addr := ParseAddress("example.com:80")
+	if !addr.IsDomain() || addr.Domain() != "example.com" {
+		t.Errorf("expected domain example.com, got %v", addr)
+	}
+}
+
+func TestAddressConstants(t *testing.T) {
+	if !LocalHostIP.IsIPv4() || LocalHostIP.IP().String() != "127.0.0.1" {
+		t.Errorf("unexpected LocalHostIP: %v", LocalHostIP)
+	}
+	if !AnyIP.IsIPv4() || AnyIP.IP().String() != "0.0.0.0" {
+		t.Errorf("unexpected AnyIP: %v", AnyIP)
+	}
+	if !LocalHostIPv6.IsIPv6() || LocalHostIPv6.IP().String() != "::1" {
+		t.Errorf("unexpected LocalHostIPv6: %v", LocalHostIPv6)
+	}
+	if !AnyIPv6.IsIPv6() || AnyIPv6.IP().String() != "::" {
+		t.Errorf("unexpected AnyIPv6: %v", AnyIPv6)
+	}
+}
@@ -0,0 +1,92 @@
+package net
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/v2ray/v2ray-core/common/log"
+)
+
+// Destination represents a network destination to be communicated with, i.e. an Address bundled
+// with a Port and the Network it should be reached over.
+type Destination struct {
+	Network Network
+	Address Address
+	Port    Port
+}
+
+// NewTCPDestination creates a Destination with TCP network.
+func NewTCPDestination(address Address, port Port) Destination {
+	return Destination{
+		Network: NetworkTCP,
+		Address: address,
+		Port:    port,
+	}
+}
+
+// NewUDPDestination creates a Destination with UDP network.
+func NewUDPDestination(address Address, port Port) Destination {
+	return Destination{
+		Network: NetworkUDP,
+		Address: address,
+		Port:    port,
+	}
+}
+
+// ParseDestination converts a destination string to a Destination. The input is in the form of
+// "network:host:port", e.g. "tcp:1.2.3.4:443" or "udp:[::1]:53".
+func ParseDestination(dest string) (Destination, error) {
+	networkAndAddress := strings.SplitN(dest, ":", 2)
+	if len(networkAndAddress) != 2 {
+		return Destination{}, log.Error("Invalid destination: %s", dest)
+	}
+
+	var network Network
+	switch strings.ToLower(networkAndAddress[0]) {
+	case "tcp":
+		network = NetworkTCP
+	case "udp":
+		network = NetworkUDP
+	default:
+		return Destination{}, log.Error("Unknown network: %s", networkAndAddress[0])
+	}
+
+	host, portStr, err := net.SplitHostPort(networkAndAddress[1])
+	if err != nil {
+		return Destination{}, log.Error("Invalid destination: %s: %v", dest, err)
+	}
+	portNum, err := strconv.Atoi(portStr)
+	if err != nil {
+		return Destination{}, log.Error("Invalid port in destination: %s: %v", dest, err)
+	}
+
+	return Destination{
+		Network: network,
+		Address: ParseAddress(host),
+		Port:    Port(portNum),
+	}, nil
+}
+
+// DestinationFromAddr translates a net.Addr, as returned by net.Listener or net.Conn, to a
+// Destination.
+func DestinationFromAddr(addr net.Addr) Destination {
+	switch typedAddr := addr.(type) {
+	case *net.TCPAddr:
+		return NewTCPDestination(IPAddress(typedAddr.IP), Port(typedAddr.Port))
+	case *net.UDPAddr:
+		return NewUDPDestination(IPAddress(typedAddr.IP), Port(typedAddr.Port))
+	default:
+		panic(log.Error("Unknown address type: %v", addr))
+	}
+}
+
+// NetAddr returns the "host:port" representation of this Destination, suitable for net.Dial.
+func (d Destination) NetAddr() string {
+	return d.Address.String() + ":" + strconv.Itoa(int(d.Port))
+}
+
+// String returns the "network:host:port" representation of this Destination.
+func (d Destination) String() string {
+	return d.Network.String() + ":" + d.NetAddr()
+}
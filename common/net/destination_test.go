@@ -0,0 +1,96 @@
+package net
+
+import (
+	"net"
+	"testing"
+)
+
+func TestParseDestinationTCP(t *testing.T) {
+	dest, err := ParseDestination("tcp:1.2.3.4:443")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.Network != NetworkTCP {
+		t.Errorf("expected NetworkTCP, got %v", dest.Network)
+	}
+	if !dest.Address.IsIPv4() || dest.Address.IP().String() != "1.2.3.4" {
+		t.Errorf("expected address 1.2.3.4, got %v", dest.Address)
+	}
+	if dest.Port != Port(443) {
+		t.Errorf("expected port 443, got %v", dest.Port)
+	}
+}
+
+func TestParseDestinationUDP(t *testing.T) {
+	dest, err := ParseDestination("udp:[::1]:53")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.Network != NetworkUDP {
+		t.Errorf("expected NetworkUDP, got %v", dest.Network)
+	}
+	if !dest.Address.IsIPv6() {
+		t.Errorf("expected an IPv6 address, got %v", dest.Address)
+	}
+	if dest.Port != Port(53) {
+		t.Errorf("expected port 53, got %v", dest.Port)
+	}
+}
+
+func TestParseDestinationDomain(t *testing.T) {
+	dest, err := ParseDestination("tcp:example.com:80")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !dest.Address.IsDomain() || dest.Address.Domain() != "example.com" {
+		t.Errorf("expected domain example.com, got %v", dest.Address)
+	}
+}
+
+func TestParseDestinationUnknownNetwork(t *testing.T) {
+	if _, err := ParseDestination("sctp:1.2.3.4:443"); err == nil {
+		t.Error("expected an error for an unknown network")
+	}
+}
+
+func TestParseDestinationMissingPort(t *testing.T) {
+	if _, err := ParseDestination("tcp:1.2.3.4"); err == nil {
+		t.Error("expected an error for a missing port")
+	}
+}
+
+func TestParseDestinationInvalidPort(t *testing.T) {
+	if _, err := ParseDestination("tcp:1.2.3.4:notaport"); err == nil {
+		t.Error("expected an error for an invalid port")
+	}
+}
+
+func TestParseDestinationMissingNetwork(t *testing.T) {
+	if _, err := ParseDestination("1.2.3.4:443"); err == nil {
+		t.Error("expected an error for a destination without a network prefix")
+	}
+}
+
+func TestDestinationFromAddr(t *testing.T) {
+	tcpAddr := &net.TCPAddr{IP: net.IPv4(1, 2, 3, 4), Port: 443}
+	dest := DestinationFromAddr(tcpAddr)
+	if dest.Network != NetworkTCP || dest.Port != Port(443) || !dest.Address.IsIPv4() {
+		t.Errorf("unexpected destination from TCPAddr: %v", dest)
+	}
+
+	udpAddr := &net.UDPAddr{IP: net.ParseIP("::1"), Port: 53}
+	dest = DestinationFromAddr(udpAddr)
+	if dest.Network != NetworkUDP || dest.Port != Port(53) || !dest.Address.IsIPv6() {
+		t.Errorf("unexpected destination from UDPAddr: %v", dest)
+	}
+}
+
+func TestDestinationNetAddrAndString(t *testing.T) {
+	dest := NewTCPDestination(IPAddress([]byte{1, 2, 3, 4}), Port(443))
+	if dest.NetAddr() != "1.2.3.4:443" {
+		t.Errorf("expected NetAddr 1.2.3.4:443, got %s", dest.NetAddr())
+	}
+	if dest.String() != "tcp:1.2.3.4:443" {
+		t.Errorf("expected String tcp:1.2.3.4:443, got %s", dest.String())
+	}
+}
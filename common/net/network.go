@@ -0,0 +1,22 @@
+package net
+
+// Network represents a transport protocol that carries a Destination.
+type Network int
+
+const (
+	NetworkUnknown = Network(iota)
+	NetworkTCP
+	NetworkUDP
+)
+
+// String returns the string representation of a Network, as used by ParseDestination.
+func (n Network) String() string {
+	switch n {
+	case NetworkTCP:
+		return "tcp"
+	case NetworkUDP:
+		return "udp"
+	default:
+		return "unknown"
+	}
+}
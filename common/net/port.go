@@ -0,0 +1,19 @@
+package net
+
+// Port represents a network port in TCP and UDP protocol.
+type Port uint16
+
+// PortFromBytes converts a byte array to a Port, assuming network byte order.
+func PortFromBytes(port []byte) Port {
+	return Port(uint16(port[0])<<8 + uint16(port[1]))
+}
+
+// Value return the corresponding uint16 value of a Port.
+func (p Port) Value() uint16 {
+	return uint16(p)
+}
+
+// Bytes returns the correspoding bytes of a Port, in network byte order.
+func (p Port) Bytes() []byte {
+	return []byte{byte(p >> 8), byte(p)}
+}